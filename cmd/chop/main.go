@@ -9,10 +9,22 @@
 //     script finds pokesprite.png in the same directory and parses the SCSS
 //     for width, height, and background-position of each .pkicon rule, then
 //     extracts each sprite into ./images/ with the correct filenames.
+//
+// Both modes accept --name, --id, --form, --game-family, --shiny/--no-shiny,
+// and --category filters (comma-separated, glob-capable) to extract just a
+// subset, and --list-names/--list-ids/--list-categories to inspect what
+// would be extracted without writing anything.
+//
+// Both modes also accept --locale (backed by --names-file, a pokemon.json
+// mapping each numeric id to a names-by-locale object) to write localized
+// filenames such as pikachu.png instead of, or alongside with --keep-id,
+// the numeric id. --name-map dumps the names of just the sprites that were
+// processed, so web consumers don't need to ship the full Pokédex.
 package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"image"
 	"image/draw"
@@ -22,6 +34,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -42,27 +55,282 @@ type Pokemon struct {
 	SkipCount int     `json:"skip_count"` // default 1
 }
 
+// spriteSelector is a sprite's identity, parsed from either a .pkicon SCSS
+// selector or a JSON-mode Pokemon entry, used both to build the output
+// filename and to evaluate --name/--id/--form/--game-family/--shiny/
+// --category filters against it.
+type spriteSelector struct {
+	ID         string // e.g. "025", "ball-love"
+	Form       string
+	GameFamily string
+	Shiny      bool
+}
+
+// category buckets a selector for --category/--list-categories: numeric IDs
+// are "pokemon"; named IDs (e.g. "ball-love") are categorized by their first
+// hyphen-delimited segment.
+func (s spriteSelector) category() string {
+	if isDigits(s.ID) {
+		return "pokemon"
+	}
+	if idx := strings.Index(s.ID, "-"); idx >= 0 {
+		return s.ID[:idx]
+	}
+	return "other"
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 func main() {
-	if len(os.Args) != 2 {
-		fmt.Println("Usage: task chop -- <filename.json|filename.scss>")
+	filters, listMode, locale := parseFilterFlags()
+
+	if flag.NArg() != 1 {
+		fmt.Println("Usage: task chop -- [filters] <filename.json|filename.scss>")
 		fmt.Println("  JSON: task chop -- ./data/spritesheet.json")
 		fmt.Println("  SCSS: task chop -- toExtractFrom/pokesprite.scss")
+		fmt.Println("  Filtered: task chop -- --id 025,001 --shiny toExtractFrom/pokesprite.scss")
+		fmt.Println("  Localized: task chop -- --locale ja toExtractFrom/pokesprite.scss")
 		os.Exit(1)
 	}
 
-	filename := os.Args[1]
+	filename := flag.Arg(0)
+
+	var processed *processedIDs
+	if locale.nameMapPath != "" {
+		processed = &processedIDs{seen: make(map[string]bool)}
+	}
 
 	if strings.HasSuffix(filename, ".scss") {
-		chopFromSCSS(filename)
+		chopFromSCSS(filename, filters, listMode, locale, processed)
+	} else {
+		chopFromJSON(filename, filters, listMode, locale, processed)
+	}
+
+	if locale.nameMapPath != "" {
+		writeNameMap(locale, processed)
+	}
+}
+
+// localeOptions controls writing localized filenames and dumping a
+// trimmed id-to-name table, all backed by an auxiliary pokemon.json names
+// file keyed by numeric id.
+type localeOptions struct {
+	table       NameTable
+	locale      string
+	keepID      bool
+	nameMapPath string
+}
+
+// NameTable maps a numeric Pokemon id to its display name in each locale,
+// e.g. table["25"]["ja"] == "ピカチュウ". It is sourced from an auxiliary
+// pokemon.json, the same file downstream tools like pokesay consume.
+type NameTable map[string]map[string]string
+
+func loadNameTable(path string) (NameTable, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var table NameTable
+	if err := json.Unmarshal(raw, &table); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// processedIDs accumulates the canonical numeric ids actually chopped (or
+// listed) so --name-map can dump just those sprites' names, not the full
+// table.
+type processedIDs struct {
+	seen map[string]bool
+}
+
+func (p *processedIDs) add(id string) {
+	if p == nil || !isDigits(id) {
 		return
 	}
+	p.seen[canonicalID(id)] = true
+}
+
+func writeNameMap(locale localeOptions, processed *processedIDs) {
+	out := make(map[string]map[string]string, len(processed.seen))
+	for id := range processed.seen {
+		if names, ok := locale.table[id]; ok {
+			out[id] = names
+		}
+	}
 
-	chopFromJSON(filename)
+	raw, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.WriteFile(locale.nameMapPath, raw, 0644); err != nil {
+		panic(err)
+	}
+}
+
+// canonicalID strips leading zeros so chop's zero-padded ids (e.g. "025")
+// line up with a pokemon.json keyed by bare numbers (e.g. "25").
+func canonicalID(id string) string {
+	if !isDigits(id) {
+		return id
+	}
+	return strconv.Itoa(atoi(id))
+}
+
+// listMode selects one of the --list-* inspection modes; empty means
+// extract sprites normally instead of just listing them.
+type listMode string
+
+const (
+	listNone       listMode = ""
+	listNames      listMode = "names"
+	listIDs        listMode = "ids"
+	listCategories listMode = "categories"
+)
+
+// filters holds the parsed --name/--id/--form/--game-family/--shiny/
+// --category criteria. Every non-empty field must match for a sprite to be
+// selected (logical AND); within a field, any one comma-separated glob
+// pattern matching is enough (logical OR).
+type filters struct {
+	names        []string
+	ids          []string
+	forms        []string
+	gameFamilies []string
+	categories   []string
+	shiny        *bool // nil means "don't filter on shiny"
+}
+
+func parseFilterFlags() (filters, listMode, localeOptions) {
+	name := flag.String("name", "", "comma-separated glob patterns to match named (non-numeric id) sprites, e.g. ball-*")
+	id := flag.String("id", "", "comma-separated glob patterns to match by output filename, e.g. 025,001 or 025* or *-shiny")
+	form := flag.String("form", "", "comma-separated glob patterns to match by form")
+	gameFamily := flag.String("game-family", "", "comma-separated glob patterns to match by game family")
+	category := flag.String("category", "", "comma-separated glob patterns to match by category (e.g. pokemon, ball)")
+	shiny := flag.Bool("shiny", false, "only extract shiny sprites")
+	noShiny := flag.Bool("no-shiny", false, "only extract non-shiny sprites")
+	listNamesFlag := flag.Bool("list-names", false, "print matching sprite names instead of extracting")
+	listIDsFlag := flag.Bool("list-ids", false, "print matching sprite ids instead of extracting")
+	listCategoriesFlag := flag.Bool("list-categories", false, "print matching sprite categories instead of extracting")
+	locale := flag.String("locale", "", "write localized filenames (e.g. pikachu.png) using this locale from --names-file")
+	namesFile := flag.String("names-file", "pokemon.json", "path to the pokemon.json names-by-locale file backing --locale and --name-map")
+	keepID := flag.Bool("keep-id", false, "with --locale, write both the numeric-id and localized filenames instead of just the localized one")
+	nameMap := flag.String("name-map", "", "write the id-to-localized-name table for every processed sprite to this file")
+	flag.Parse()
+
+	f := filters{
+		names:        splitCSV(*name),
+		ids:          splitCSV(*id),
+		forms:        splitCSV(*form),
+		gameFamilies: splitCSV(*gameFamily),
+		categories:   splitCSV(*category),
+	}
+	switch {
+	case *shiny && *noShiny:
+		fmt.Fprintln(os.Stderr, "chop: --shiny and --no-shiny are mutually exclusive")
+		os.Exit(1)
+	case *shiny:
+		v := true
+		f.shiny = &v
+	case *noShiny:
+		v := false
+		f.shiny = &v
+	}
+
+	mode := listNone
+	switch {
+	case *listNamesFlag:
+		mode = listNames
+	case *listIDsFlag:
+		mode = listIDs
+	case *listCategoriesFlag:
+		mode = listCategories
+	}
+
+	loc := localeOptions{locale: *locale, keepID: *keepID, nameMapPath: *nameMap}
+	if *locale != "" || *nameMap != "" {
+		table, err := loadNameTable(*namesFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "chop: --locale/--name-map requires a readable names file: %v\n", err)
+			os.Exit(1)
+		}
+		loc.table = table
+	}
+
+	return f, mode, loc
+}
+
+func splitCSV(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+// matches reports whether sel satisfies every filter that was set. An empty
+// filter field always matches. --id matches against the output filename
+// stem (e.g. "025-shiny") rather than the bare id, so patterns like "025*"
+// or "*-shiny" select every variant of a Pokemon or every shiny regardless
+// of id.
+func (f filters) matches(sel spriteSelector, filename string) bool {
+	stem := strings.TrimSuffix(filename, ".png")
+	if !matchAny(f.ids, stem) {
+		return false
+	}
+	if len(f.names) > 0 && isDigits(sel.ID) {
+		// --name only applies to named (non-numeric) sprites.
+		return false
+	}
+	if !matchAny(f.names, sel.ID) {
+		return false
+	}
+	if !matchAny(f.forms, sel.Form) {
+		return false
+	}
+	if !matchAny(f.gameFamilies, sel.GameFamily) {
+		return false
+	}
+	if !matchAny(f.categories, sel.category()) {
+		return false
+	}
+	if f.shiny != nil && *f.shiny != sel.Shiny {
+		return false
+	}
+	return true
+}
+
+// matchAny reports whether value matches any of patterns, or true if
+// patterns is empty (no filter set on that field).
+func matchAny(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, err := filepath.Match(pattern, value); err == nil && ok {
+			return true
+		}
+	}
+	return false
 }
 
 // chopFromSCSS reads a pokesprite.scss and pokesprite.png from the same
 // directory and extracts each sprite into ./images/.
-func chopFromSCSS(scssPath string) {
+func chopFromSCSS(scssPath string, f filters, mode listMode, locale localeOptions, processed *processedIDs) {
 	dir := filepath.Dir(scssPath)
 	pngPath := filepath.Join(dir, "pokesprite.png")
 
@@ -70,14 +338,18 @@ func chopFromSCSS(scssPath string) {
 	if err != nil {
 		panic(err)
 	}
-	imgFile, err := os.Open(pngPath)
-	if err != nil {
-		panic(fmt.Errorf("open spritesheet %s: %w", pngPath, err))
-	}
-	defer imgFile.Close()
-	img, _, err := image.Decode(imgFile)
-	if err != nil {
-		panic(err)
+
+	var img image.Image
+	if mode == listNone {
+		imgFile, err := os.Open(pngPath)
+		if err != nil {
+			panic(fmt.Errorf("open spritesheet %s: %w", pngPath, err))
+		}
+		defer imgFile.Close()
+		img, _, err = image.Decode(imgFile)
+		if err != nil {
+			panic(err)
+		}
 	}
 
 	// Match lines like: .pkicon.pkicon-001.color-shiny { width: 20px; height: 19px; background-position: 0px -56px; }
@@ -85,6 +357,8 @@ func chopFromSCSS(scssPath string) {
 	lineRE := regexp.MustCompile(`\.pkicon\.pkicon-([^\s{]+)\s*\{\s*width:\s*(\d+)px;\s*height:\s*(\d+)px;\s*background-position:\s*(-?\d+)px\s*(-?\d+)px;`)
 	lines := strings.Split(string(raw), "\n")
 
+	seen := make(map[string]bool)
+
 	for _, line := range lines {
 		matches := lineRE.FindStringSubmatch(line)
 		if matches == nil {
@@ -96,11 +370,26 @@ func chopFromSCSS(scssPath string) {
 		bgX := atoi(matches[4])
 		bgY := atoi(matches[5])
 
-		outName := scssSelectorToFilename(selectorParts)
+		sel := parseSCSSSelector(selectorParts)
+		if sel.ID == "" {
+			continue
+		}
+
+		outName := sel.filename()
 		if outName == "" {
 			continue
 		}
 
+		if !f.matches(sel, outName) {
+			continue
+		}
+		processed.add(sel.ID)
+
+		if mode != listNone {
+			printListEntry(mode, sel, displayName(sel, outName, locale), seen)
+			continue
+		}
+
 		// CSS background-position is the offset of the sprite (negative in the sheet).
 		srcX := -bgX
 		srcY := -bgY
@@ -113,17 +402,43 @@ func chopFromSCSS(scssPath string) {
 		rgba := image.NewRGBA(r)
 		draw.Draw(rgba, r, img, image.Point{srcX, srcY}, draw.Src)
 
-		outPath := filepath.Join("images", outName)
-		out, err := os.Create(outPath)
-		if err != nil {
-			panic(err)
-		}
-		if err := png.Encode(out, rgba); err != nil {
-			out.Close()
-			panic(err)
+		for _, name := range outputNames(sel, outName, locale) {
+			writeSpritePNG(name, rgba)
 		}
+	}
+}
+
+// writeSpritePNG encodes rgba to images/<name>.
+func writeSpritePNG(name string, rgba *image.RGBA) {
+	out, err := os.Create(filepath.Join("images", name))
+	if err != nil {
+		panic(err)
+	}
+	if err := png.Encode(out, rgba); err != nil {
 		out.Close()
+		panic(err)
 	}
+	out.Close()
+}
+
+// printListEntry prints one line for the active --list-* mode, deduplicating
+// against entries already printed (e.g. --list-categories should only print
+// "pokemon" once).
+func printListEntry(mode listMode, sel spriteSelector, filename string, seen map[string]bool) {
+	var value string
+	switch mode {
+	case listNames:
+		value = filename
+	case listIDs:
+		value = sel.ID
+	case listCategories:
+		value = sel.category()
+	}
+	if seen[value] {
+		return
+	}
+	seen[value] = true
+	fmt.Println(value)
 }
 
 func atoi(s string) int {
@@ -132,48 +447,96 @@ func atoi(s string) int {
 	return n
 }
 
-// scssSelectorToFilename converts the middle part of a .pkicon.pkicon-XXX... selector
-// to a filename matching the repo convention: 001.png, 001-shiny.png, 025-legends_arceus.png, love-ball.png, etc.
-func scssSelectorToFilename(parts string) string {
+// parseSCSSSelector parses the middle part of a .pkicon.pkicon-XXX...
+// selector into its id/form/game-family/shiny components.
+func parseSCSSSelector(parts string) spriteSelector {
 	segments := strings.Split(parts, ".")
-	var id string
-	var form, gameFamily string
-	var shiny bool
+	var sel spriteSelector
 	for _, s := range segments {
 		switch {
 		case s == "color-shiny":
-			shiny = true
+			sel.Shiny = true
 		case strings.HasPrefix(s, "form-"):
-			form = strings.TrimPrefix(s, "form-")
+			sel.Form = strings.TrimPrefix(s, "form-")
 		case strings.HasPrefix(s, "game-family-"):
-			gameFamily = strings.TrimPrefix(s, "game-family-")
+			sel.GameFamily = strings.TrimPrefix(s, "game-family-")
 		case strings.HasPrefix(s, "pkicon-"):
 			// shouldn't appear in this segment string
 		default:
 			// id: "001" or "ball-love" or "025"
-			id = s
+			sel.ID = s
 		}
 	}
-	if id == "" {
+	return sel
+}
+
+// filename converts a selector to the filename convention this repo uses:
+// 001.png, 001-shiny.png, 025-legends_arceus.png, love-ball.png, etc.
+func (s spriteSelector) filename() string {
+	if s.ID == "" {
 		return ""
 	}
-	if id == "ball-love" {
+	if s.ID == "ball-love" {
 		return "love-ball.png"
 	}
-	name := id
-	if shiny {
+	return s.filenameWithBase(s.ID)
+}
+
+// localizedFilename is like filename, but swaps the numeric id for its
+// display name in the given locale (e.g. "pikachu" instead of "025"),
+// falling back to filename's own numeric-id convention (including its
+// "ball-love" -> "love-ball.png" special case) when no name is found.
+func (s spriteSelector) localizedFilename(table NameTable, locale string) string {
+	if names, ok := table[canonicalID(s.ID)]; ok {
+		if n, ok := names[locale]; ok && n != "" {
+			return s.filenameWithBase(n)
+		}
+	}
+	return s.filename()
+}
+
+func (s spriteSelector) filenameWithBase(base string) string {
+	if base == "" {
+		return ""
+	}
+	name := base
+	if s.Shiny {
 		name += "-shiny"
 	}
-	if gameFamily != "" {
-		name += "-" + gameFamily
+	if s.GameFamily != "" {
+		name += "-" + s.GameFamily
 	}
-	if form != "" {
-		name += "-" + form
+	if s.Form != "" {
+		name += "-" + s.Form
 	}
 	return name + ".png"
 }
 
-func chopFromJSON(filename string) {
+// outputNames returns the filename(s) a sprite should be written as: just
+// the localized name if --locale is set (unless --keep-id also asked for
+// the numeric one alongside it), or just numericName otherwise.
+func outputNames(sel spriteSelector, numericName string, locale localeOptions) []string {
+	if locale.locale == "" {
+		return []string{numericName}
+	}
+	localized := sel.localizedFilename(locale.table, locale.locale)
+	if locale.keepID {
+		return []string{numericName, localized}
+	}
+	return []string{localized}
+}
+
+// displayName is the name --list-names should print for sel: the localized
+// filename when --locale is set (matching what would actually be written),
+// otherwise the plain numeric filename.
+func displayName(sel spriteSelector, numericName string, locale localeOptions) string {
+	if locale.locale == "" {
+		return numericName
+	}
+	return sel.localizedFilename(locale.table, locale.locale)
+}
+
+func chopFromJSON(filename string, f filters, mode listMode, locale localeOptions, processed *processedIDs) {
 	raw, err := ioutil.ReadFile(filename)
 	if err != nil {
 		panic(err)
@@ -185,20 +548,27 @@ func chopFromJSON(filename string) {
 		panic(err)
 	}
 
-	spritesheet, err := os.Open(data.Filename)
-	if err != nil {
-		panic(err)
-	}
-	img, _, err := image.Decode(spritesheet)
-	spritesheet.Close()
-	if err != nil {
-		panic(err)
+	var img image.Image
+	if mode == listNone {
+		spritesheet, err := os.Open(data.Filename)
+		if err != nil {
+			panic(err)
+		}
+		img, _, err = image.Decode(spritesheet)
+		spritesheet.Close()
+		if err != nil {
+			panic(err)
+		}
 	}
 
 	// height = (total height of spritesheet - ((rows + 1) * outline size)) / rows
-	height := (img.Bounds().Size().Y - ((data.Rows + 1) * data.Outline)) / data.Rows
-	width := (img.Bounds().Size().X - ((data.Columns + 1) * data.Outline)) / data.Columns
+	var height, width int
+	if img != nil {
+		height = (img.Bounds().Size().Y - ((data.Rows + 1) * data.Outline)) / data.Rows
+		width = (img.Bounds().Size().X - ((data.Columns + 1) * data.Outline)) / data.Columns
+	}
 
+	seen := make(map[string]bool)
 	spriteIndex := 0
 	for _, pokemon := range data.Pokemon {
 		if pokemon.Skip {
@@ -209,17 +579,19 @@ func chopFromJSON(filename string) {
 			continue
 		}
 
-		// Calculate which row and column we're on based on the index.
-		row := spriteIndex / data.Columns
-		column := spriteIndex % data.Columns
-
-		// Create new image data.
-		r := image.Rectangle{image.Point{0, 0}, image.Point{width - 2*data.Padding, height - 2*data.Padding}}
-		rgba := image.NewRGBA(r)
-		draw.Draw(rgba, r.Bounds(), img, image.Point{column*height + (column+1)*data.Outline + data.Padding, row*width + (row+1)*data.Outline + data.Padding}, draw.Src)
+		sel := spriteSelector{ID: fmt.Sprintf("%03d", pokemon.ID)}
+		if pokemon.Form != nil {
+			sel.Form = *pokemon.Form
+		}
+		if data.Suffix != nil {
+			if *data.Suffix == "shiny" {
+				sel.Shiny = true
+			} else {
+				sel.GameFamily = *data.Suffix
+			}
+		}
 
-		// Generate the new filename.
-		outName := fmt.Sprintf("./images/%03d", pokemon.ID)
+		outName := sel.ID
 		if data.Suffix != nil {
 			outName += "-" + *data.Suffix
 		}
@@ -228,16 +600,30 @@ func chopFromJSON(filename string) {
 		}
 		outName += ".png"
 
-		// Write the new chopped up png out.
-		out, err := os.Create(outName)
-		if err != nil {
-			panic(err)
+		if !f.matches(sel, outName) {
+			spriteIndex++
+			continue
 		}
-		encoder := png.Encoder{}
-		err = encoder.Encode(out, rgba)
-		out.Close()
-		if err != nil {
-			panic(err)
+		processed.add(sel.ID)
+
+		if mode != listNone {
+			printListEntry(mode, sel, displayName(sel, outName, locale), seen)
+			spriteIndex++
+			continue
+		}
+
+		// Calculate which row and column we're on based on the index.
+		row := spriteIndex / data.Columns
+		column := spriteIndex % data.Columns
+
+		// Create new image data.
+		r := image.Rectangle{image.Point{0, 0}, image.Point{width - 2*data.Padding, height - 2*data.Padding}}
+		rgba := image.NewRGBA(r)
+		draw.Draw(rgba, r.Bounds(), img, image.Point{column*height + (column+1)*data.Outline + data.Padding, row*width + (row+1)*data.Outline + data.Padding}, draw.Src)
+
+		// Write the new chopped up png out.
+		for _, name := range outputNames(sel, outName, locale) {
+			writeSpritePNG(name, rgba)
 		}
 
 		spriteIndex++
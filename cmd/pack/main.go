@@ -0,0 +1,345 @@
+// This script inverts chop: given a directory of individual sprite PNGs
+// (001.png, 025-shiny.png, 025-legends_arceus-cap.png, ...) it packs them
+// into a single pokesprite.png atlas plus a pokesprite.scss compatible with
+// chopFromSCSS (cmd/chop) and extractSpritePositions (cmd/positions), so an
+// atlas can be rebuilt after editing individual sprites.
+//
+// Usage:
+//
+//	task pack -- <images-dir> <output-dir>
+//	task pack -- ./images ./output
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	_ "image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Sprite is a single loose PNG parsed back into the id/form/game-family/shiny
+// components that make up a .pkicon selector, along with its decoded pixels.
+type Sprite struct {
+	ID         string
+	Form       string
+	GameFamily string
+	Shiny      bool
+
+	Img  image.Image
+	W, H int
+	X, Y int // position assigned by the packer, set after packRects
+}
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Fprintln(os.Stderr, "Usage: task pack -- <images-dir> <output-dir>")
+		fmt.Fprintln(os.Stderr, "Example: task pack -- ./images ./output")
+		os.Exit(1)
+	}
+
+	imagesDir := os.Args[1]
+	outDir := os.Args[2]
+
+	sprites, err := loadSprites(imagesDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pack: %v\n", err)
+		os.Exit(1)
+	}
+	if len(sprites) == 0 {
+		fmt.Fprintf(os.Stderr, "pack: no PNGs found in %s\n", imagesDir)
+		os.Exit(1)
+	}
+
+	width, height := packRects(sprites)
+
+	atlas := image.NewRGBA(image.Rect(0, 0, width, height))
+	for _, s := range sprites {
+		dst := image.Rect(s.X, s.Y, s.X+s.W, s.Y+s.H)
+		draw.Draw(atlas, dst, s.Img, image.Point{}, draw.Src)
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "pack: %v\n", err)
+		os.Exit(1)
+	}
+
+	pngPath := filepath.Join(outDir, "pokesprite.png")
+	out, err := os.Create(pngPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pack: %v\n", err)
+		os.Exit(1)
+	}
+	err = png.Encode(out, atlas)
+	out.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pack: %v\n", err)
+		os.Exit(1)
+	}
+
+	scssPath := filepath.Join(outDir, "pokesprite.scss")
+	if err := os.WriteFile(scssPath, []byte(buildSCSS(sprites)), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "pack: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Packed %d sprites into %s (%dx%d) and %s\n", len(sprites), pngPath, width, height, scssPath)
+}
+
+// loadSprites reads every .png in dir and parses its filename and pixels.
+func loadSprites(dir string) ([]*Sprite, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var sprites []*Sprite
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".png") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		img, _, err := image.Decode(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", path, err)
+		}
+
+		s := filenameToSprite(strings.TrimSuffix(entry.Name(), ".png"))
+		s.Img = img
+		s.W = img.Bounds().Dx()
+		s.H = img.Bounds().Dy()
+		sprites = append(sprites, s)
+	}
+	return sprites, nil
+}
+
+// filenameToSprite parses the repo's chop filename convention,
+// <id>[-shiny][-gameFamily][-form], back into its parts. This is the inverse
+// of spriteSelector.filename in cmd/chop. Filenames with a single segment
+// after the id (e.g. "025-cap.png") are ambiguous between a game family and
+// a form; since every multi-word game family seen in this repo uses
+// underscores rather than hyphens, a single remaining segment is treated as
+// a form.
+//
+// This -shiny/-gameFamily/-form split only applies to numeric Pokemon ids,
+// where chop always builds the filename in that fixed order. Named ids
+// (e.g. "ball-love", and presumably other ball-*/item-* ids this repo
+// doesn't have an example of yet) have no such documented convention — a
+// name like "ball-poke" could be one opaque id, or an id plus a form, and
+// guessing wrong would silently corrupt the selector. So named ids are
+// never split: the whole stem becomes the id, matching the one convention
+// we do know (spriteSelector.filename emits "love-ball.png" for the single,
+// dot-free selector "ball-love", i.e. no split at all).
+func filenameToSprite(stem string) *Sprite {
+	if stem == "love-ball" {
+		return &Sprite{ID: "ball-love"}
+	}
+
+	parts := strings.Split(stem, "-")
+	if !isDigits(parts[0]) {
+		if len(parts) > 1 {
+			fmt.Fprintf(os.Stderr, "pack: %q has a non-numeric id with multiple segments; treating it as one opaque id instead of guessing a form/game-family split\n", stem)
+		}
+		return &Sprite{ID: stem}
+	}
+
+	s := &Sprite{ID: parts[0]}
+	rest := parts[1:]
+
+	if len(rest) > 0 && rest[0] == "shiny" {
+		s.Shiny = true
+		rest = rest[1:]
+	}
+
+	switch len(rest) {
+	case 1:
+		s.Form = rest[0]
+	case 2:
+		s.GameFamily = rest[0]
+		s.Form = rest[1]
+	}
+
+	return s
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// selector renders the .pkicon-<id>[.form-X][.game-family-Y][.color-shiny]
+// selector suffix, in the segment order extractSpritePositions expects.
+func (s *Sprite) selector() string {
+	var b strings.Builder
+	b.WriteString("pkicon-")
+	b.WriteString(s.ID)
+	if s.Form != "" {
+		b.WriteString(".form-")
+		b.WriteString(s.Form)
+	}
+	if s.GameFamily != "" {
+		b.WriteString(".game-family-")
+		b.WriteString(s.GameFamily)
+	}
+	if s.Shiny {
+		b.WriteString(".color-shiny")
+	}
+	return b.String()
+}
+
+// buildSCSS emits one .pkicon rule per sprite, matching the format both
+// chopFromSCSS and extractSpritePositions parse line-by-line.
+func buildSCSS(sprites []*Sprite) string {
+	var b strings.Builder
+	for _, s := range sprites {
+		fmt.Fprintf(&b, ".pkicon.%s { width: %dpx; height: %dpx; background-position: %dpx %dpx; }\n",
+			s.selector(), s.W, s.H, -s.X, -s.Y)
+	}
+	return b.String()
+}
+
+// skylineSegment is one run of the current packing frontier.
+type skylineSegment struct {
+	x, y, width int
+}
+
+// packRects runs a skyline bin-packing pass: sprites are sorted tallest
+// first, then each is placed at the lowest-y, leftmost-x position on the
+// skyline that fits, growing the atlas height as needed. Width starts at a
+// power-of-two estimate of sqrt(total area) and is doubled if a sprite
+// doesn't fit horizontally anywhere.
+func packRects(sprites []*Sprite) (width, height int) {
+	sort.SliceStable(sprites, func(i, j int) bool {
+		return sprites[i].H > sprites[j].H
+	})
+
+	var totalArea, maxW int
+	for _, s := range sprites {
+		totalArea += s.W * s.H
+		if s.W > maxW {
+			maxW = s.W
+		}
+	}
+	width = nextPowerOfTwo(int(math.Sqrt(float64(totalArea))))
+	for width < maxW {
+		width *= 2
+	}
+
+	for {
+		skyline := []skylineSegment{{x: 0, y: 0, width: width}}
+		maxY := 0
+		fits := true
+
+		for _, s := range sprites {
+			x, y, idx, ok := bestSkylinePosition(skyline, s.W, width)
+			if !ok {
+				fits = false
+				break
+			}
+			s.X, s.Y = x, y
+			if y+s.H > maxY {
+				maxY = y + s.H
+			}
+			skyline = insertSkylineSegment(skyline, idx, x, y+s.H, s.W, width)
+		}
+
+		if fits {
+			return width, maxY
+		}
+		width *= 2
+	}
+}
+
+// bestSkylinePosition finds the lowest-y, then leftmost-x segment index at
+// which a rect of the given width fits without exceeding the atlas width.
+func bestSkylinePosition(skyline []skylineSegment, w, atlasWidth int) (x, y, idx int, ok bool) {
+	bestY := math.MaxInt32
+	bestX := math.MaxInt32
+	bestIdx := -1
+
+	for i, seg := range skyline {
+		if seg.x+w > atlasWidth {
+			continue
+		}
+		segY := heightUnder(skyline, i, w)
+		if segY < bestY || (segY == bestY && seg.x < bestX) {
+			bestY = segY
+			bestX = seg.x
+			bestIdx = i
+		}
+	}
+
+	if bestIdx == -1 {
+		return 0, 0, 0, false
+	}
+	return bestX, bestY, bestIdx, true
+}
+
+// heightUnder returns the highest y-value among the skyline segments a rect
+// of width w would span, starting at segment i.
+func heightUnder(skyline []skylineSegment, i, w int) int {
+	maxY := 0
+	remaining := w
+	for j := i; j < len(skyline) && remaining > 0; j++ {
+		if skyline[j].y > maxY {
+			maxY = skyline[j].y
+		}
+		remaining -= skyline[j].width
+	}
+	return maxY
+}
+
+// insertSkylineSegment replaces the skyline under [x, x+w) with a single new
+// segment at height newY, splitting or consuming neighboring segments as
+// needed to keep the skyline contiguous and sorted by x.
+func insertSkylineSegment(skyline []skylineSegment, startIdx, x, newY, w, atlasWidth int) []skylineSegment {
+	end := x + w
+	var result []skylineSegment
+	result = append(result, skyline[:startIdx]...)
+	result = append(result, skylineSegment{x: x, y: newY, width: w})
+
+	for i := startIdx; i < len(skyline); i++ {
+		seg := skyline[i]
+		segEnd := seg.x + seg.width
+		if segEnd <= end {
+			continue // fully covered by the new segment
+		}
+		if seg.x < end {
+			// Partially covered: keep the remainder to the right.
+			result = append(result, skylineSegment{x: end, y: seg.y, width: segEnd - end})
+			continue
+		}
+		result = append(result, seg)
+	}
+
+	return result
+}
+
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p *= 2
+	}
+	if p == 0 {
+		p = 1
+	}
+	return p
+}
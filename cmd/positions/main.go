@@ -1,47 +1,116 @@
-// This script reads a pokesprite.scss file and generates a TypeScript file
-// containing a map of sprite positions for use in a web application.
+// This script reads a pokesprite.scss file and generates a sprite position
+// atlas in one of several output formats for use by other applications.
 //
 // Usage:
 //
 //	task positions                                         # uses defaults
 //	task positions -- <input.scss> <output.ts>             # custom paths
+//	task positions -- --format json <input.scss> <output>  # explicit format
 //
 // Defaults:
 //
 //	input:  ./output/pokesprite.scss
 //	output: ./output/sprite-positions.ts
+//
+// The output format is chosen by --format, falling back to the output
+// file's extension, falling back to "ts". Supported formats: ts, json, css,
+// sass, go.
+//
+// --locale (backed by --names-file, a pokemon.json mapping each numeric id
+// to a names-by-locale object) attaches a DisplayName to each emitted
+// position, sourced from the same pokemon.json downstream tools like
+// pokesay consume.
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 )
 
 type SpritePosition struct {
+	ID                 string `json:"-"` // numeric Pokemon id, used to look up DisplayName
 	Width              int    `json:"width"`
 	Height             int    `json:"height"`
 	BackgroundPosition string `json:"backgroundPosition"`
+	DisplayName        string `json:"displayName"`
+}
+
+// NameTable maps a numeric Pokemon id to its display name in each locale,
+// e.g. table["25"]["ja"] == "ピカチュウ".
+type NameTable map[string]map[string]string
+
+func loadNameTable(path string) (NameTable, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var table NameTable
+	if err := json.Unmarshal(raw, &table); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// PositionEmitter writes a sprite position atlas, in whatever format it
+// implements, to w. order preserves the sequence positions were first seen
+// in the source SCSS.
+type PositionEmitter interface {
+	Emit(w io.Writer, order []string, positions map[string]SpritePosition) error
+}
+
+// emitters is the registry of built-in output formats, keyed by the name
+// passed to --format (and by the output file extension it matches).
+var emitters = map[string]PositionEmitter{
+	"ts":   tsEmitter{},
+	"json": jsonEmitter{},
+	"css":  cssEmitter{},
+	"sass": sassEmitter{},
+	"go":   goEmitter{},
 }
 
 func main() {
+	format := flag.String("format", "", "output format: ts, json, css, sass, go (defaults to the output file's extension, then ts)")
+	locale := flag.String("locale", "", "attach a DisplayName in this locale to each position, from --names-file")
+	namesFile := flag.String("names-file", "pokemon.json", "path to the pokemon.json names-by-locale file backing --locale")
+	flag.Parse()
+
 	scssPath := "./output/pokesprite.scss"
 	outPath := "./output/sprite-positions.ts"
 
-	switch len(os.Args) {
-	case 1:
+	switch flag.NArg() {
+	case 0:
 		// use defaults
-	case 3:
-		scssPath = os.Args[1]
-		outPath = os.Args[2]
+	case 2:
+		scssPath = flag.Arg(0)
+		outPath = flag.Arg(1)
 	default:
-		fmt.Fprintln(os.Stderr, "Usage: task positions -- [<input.scss> <output.ts>]")
-		fmt.Fprintln(os.Stderr, "Example: task positions -- output/pokesprite.scss output/sprite-positions.ts")
+		fmt.Fprintln(os.Stderr, "Usage: task positions -- [--format <ts|json|css|sass|go>] [--locale <code>] [<input.scss> <output>]")
+		fmt.Fprintln(os.Stderr, "Example: task positions -- --format json output/pokesprite.scss output/sprite-positions.json")
+		os.Exit(1)
+	}
+
+	emitter, err := resolveEmitter(*format, outPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
+	var names NameTable
+	if *locale != "" {
+		names, err = loadNameTable(*namesFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --locale requires a readable names file: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	fmt.Println("Reading SCSS file...")
 	raw, err := os.ReadFile(scssPath)
 	if err != nil {
@@ -53,8 +122,12 @@ func main() {
 	positions, order := extractSpritePositions(string(raw))
 	fmt.Printf("Found %d sprite positions\n", len(positions))
 
+	if *locale != "" {
+		attachDisplayNames(positions, names, *locale)
+	}
+
 	fmt.Println("Writing output file...")
-	if err := writeTS(outPath, positions, order); err != nil {
+	if err := writeEmitted(outPath, emitter, positions, order); err != nil {
 		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", outPath, err)
 		os.Exit(1)
 	}
@@ -62,6 +135,48 @@ func main() {
 	fmt.Printf("Successfully generated %s\n", outPath)
 }
 
+// attachDisplayNames fills in DisplayName on every position whose id is
+// found in names for locale, leaving the rest blank.
+func attachDisplayNames(positions map[string]SpritePosition, names NameTable, locale string) {
+	for key, p := range positions {
+		if byLocale, ok := names[p.ID]; ok {
+			p.DisplayName = byLocale[locale]
+			positions[key] = p
+		}
+	}
+}
+
+// resolveEmitter picks a PositionEmitter by explicit --format, falling back
+// to the output path's extension, falling back to "ts".
+func resolveEmitter(format, outPath string) (PositionEmitter, error) {
+	name := format
+	if name == "" {
+		switch ext := strings.TrimPrefix(filepath.Ext(outPath), "."); ext {
+		case "ts", "json", "css", "go":
+			name = ext
+		case "scss":
+			name = "sass"
+		default:
+			name = "ts"
+		}
+	}
+
+	emitter, ok := emitters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q (supported: ts, json, css, sass, go)", name)
+	}
+	return emitter, nil
+}
+
+func writeEmitted(path string, emitter PositionEmitter, positions map[string]SpritePosition, order []string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return emitter.Emit(f, order, positions)
+}
+
 // lineRE matches lines like:
 // .pkicon.pkicon-025.form-cap.game-family-legends_arceus.color-shiny { width: 21px; height: 20px; background-position: -67px -56px; }
 var lineRE = regexp.MustCompile(
@@ -86,7 +201,8 @@ func extractSpritePositions(scss string) (map[string]SpritePosition, []string) {
 		height := atoi(m[6])
 		xPos, yPos := m[7], m[8]
 
-		key := "pokemon-" + strconv.Itoa(atoi(pokemonID))
+		id := strconv.Itoa(atoi(pokemonID))
+		key := "pokemon-" + id
 		if form != "" {
 			key += "-" + form
 		}
@@ -98,6 +214,7 @@ func extractSpritePositions(scss string) (map[string]SpritePosition, []string) {
 		}
 
 		positions[key] = SpritePosition{
+			ID:                 id,
 			Width:              width,
 			Height:             height,
 			BackgroundPosition: xPos + "px " + yPos + "px",
@@ -108,9 +225,26 @@ func extractSpritePositions(scss string) (map[string]SpritePosition, []string) {
 	return positions, order
 }
 
-func writeTS(path string, positions map[string]SpritePosition, order []string) error {
-	var b strings.Builder
+// spriteSheetCoords converts a "-67px -56px" backgroundPosition into the
+// sprite's positive (x, y) offset within the spritesheet.
+func spriteSheetCoords(p SpritePosition) (x, y int) {
+	fields := strings.Fields(p.BackgroundPosition)
+	if len(fields) != 2 {
+		return 0, 0
+	}
+	return -atoi(strings.TrimSuffix(fields[0], "px")), -atoi(strings.TrimSuffix(fields[1], "px"))
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// tsEmitter writes the TypeScript module the web app has always consumed.
+type tsEmitter struct{}
 
+func (tsEmitter) Emit(w io.Writer, order []string, positions map[string]SpritePosition) error {
+	var b strings.Builder
 	b.WriteString("// Auto-generated from pokesprite.scss\n")
 	b.WriteString("// Do not edit manually\n")
 	b.WriteString("\n")
@@ -123,18 +257,119 @@ func writeTS(path string, positions map[string]SpritePosition, order []string) e
 
 	for _, key := range order {
 		p := positions[key]
-		b.WriteString(fmt.Sprintf(
-			"  %q: { width: %d, height: %d, backgroundPosition: %q },\n",
-			key, p.Width, p.Height, p.BackgroundPosition,
-		))
+		fmt.Fprintf(&b, "  %q: { width: %d, height: %d, backgroundPosition: %q, displayName: %q },\n",
+			key, p.Width, p.Height, p.BackgroundPosition, p.DisplayName)
 	}
 
 	b.WriteString("};\n")
 
-	return os.WriteFile(path, []byte(b.String()), 0644)
+	_, err := w.Write([]byte(b.String()))
+	return err
 }
 
-func atoi(s string) int {
-	n, _ := strconv.Atoi(s)
-	return n
+// jsonEmitter writes a TexturePacker/Phaser-style JSON-hash atlas.
+type jsonEmitter struct{}
+
+func (jsonEmitter) Emit(w io.Writer, order []string, positions map[string]SpritePosition) error {
+	var b strings.Builder
+	b.WriteString("{\n  \"frames\": {\n")
+
+	for i, key := range order {
+		p := positions[key]
+		x, y := spriteSheetCoords(p)
+		fmt.Fprintf(&b, "    %q: {\n", key)
+		fmt.Fprintf(&b, "      \"frame\": { \"x\": %d, \"y\": %d, \"w\": %d, \"h\": %d },\n", x, y, p.Width, p.Height)
+		b.WriteString("      \"rotated\": false,\n")
+		b.WriteString("      \"trimmed\": false,\n")
+		fmt.Fprintf(&b, "      \"sourceSize\": { \"w\": %d, \"h\": %d },\n", p.Width, p.Height)
+		fmt.Fprintf(&b, "      \"displayName\": %q\n", p.DisplayName)
+		if i < len(order)-1 {
+			b.WriteString("    },\n")
+		} else {
+			b.WriteString("    }\n")
+		}
+	}
+
+	b.WriteString("  }\n}\n")
+
+	_, err := w.Write([]byte(b.String()))
+	return err
+}
+
+// cssEmitter writes one .pkicon-<key> rule per sprite.
+type cssEmitter struct{}
+
+func (cssEmitter) Emit(w io.Writer, order []string, positions map[string]SpritePosition) error {
+	var b strings.Builder
+	b.WriteString("/* Auto-generated from pokesprite.scss */\n")
+	b.WriteString("/* Do not edit manually */\n\n")
+
+	for _, key := range order {
+		p := positions[key]
+		if p.DisplayName != "" {
+			fmt.Fprintf(&b, "/* %s */\n", p.DisplayName)
+		}
+		fmt.Fprintf(&b, ".pkicon-%s { width: %dpx; height: %dpx; background-position: %s; }\n",
+			key, p.Width, p.Height, p.BackgroundPosition)
+	}
+
+	_, err := w.Write([]byte(b.String()))
+	return err
+}
+
+// sassEmitter writes a @mixin sprite($name) that switches on sprite key.
+type sassEmitter struct{}
+
+func (sassEmitter) Emit(w io.Writer, order []string, positions map[string]SpritePosition) error {
+	var b strings.Builder
+	b.WriteString("// Auto-generated from pokesprite.scss\n")
+	b.WriteString("// Do not edit manually\n\n")
+	b.WriteString("@mixin sprite($name) {\n")
+
+	for i, key := range order {
+		p := positions[key]
+		branch := "@else if"
+		if i == 0 {
+			branch = "@if"
+		}
+		fmt.Fprintf(&b, "  %s $name == %q {\n", branch, key)
+		if p.DisplayName != "" {
+			fmt.Fprintf(&b, "    // %s\n", p.DisplayName)
+		}
+		fmt.Fprintf(&b, "    width: %dpx;\n    height: %dpx;\n    background-position: %s;\n", p.Width, p.Height, p.BackgroundPosition)
+		b.WriteString("  }\n")
+	}
+
+	b.WriteString("}\n")
+
+	_, err := w.Write([]byte(b.String()))
+	return err
+}
+
+// goEmitter writes a Go source file with a map of sprite rects.
+type goEmitter struct{}
+
+func (goEmitter) Emit(w io.Writer, order []string, positions map[string]SpritePosition) error {
+	var b strings.Builder
+	b.WriteString("// Code generated from pokesprite.scss. DO NOT EDIT.\n\n")
+	b.WriteString("package sprites\n\n")
+	b.WriteString("// Rect describes a sprite's dimensions and its position in the atlas.\n")
+	b.WriteString("type Rect struct {\n")
+	b.WriteString("\tWidth              int\n")
+	b.WriteString("\tHeight             int\n")
+	b.WriteString("\tBackgroundPosition string\n")
+	b.WriteString("\tDisplayName        string\n")
+	b.WriteString("}\n\n")
+	b.WriteString("var Positions = map[string]Rect{\n")
+
+	for _, key := range order {
+		p := positions[key]
+		fmt.Fprintf(&b, "\t%q: {Width: %d, Height: %d, BackgroundPosition: %q, DisplayName: %q},\n",
+			key, p.Width, p.Height, p.BackgroundPosition, p.DisplayName)
+	}
+
+	b.WriteString("}\n")
+
+	_, err := w.Write([]byte(b.String()))
+	return err
 }
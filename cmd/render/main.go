@@ -0,0 +1,304 @@
+// This script renders an extracted sprite PNG directly to the terminal using
+// 256-color (or truecolor) ANSI escapes, so pokesprite's assets are usable
+// from the shell without a browser, the way pokesay does.
+//
+// Usage:
+//
+//	task render -- <path.png|name>
+//	task render -- 025
+//	task render -- 025-shiny --truecolor
+//
+// A bare name (no path separator) is resolved against ./images/<name>.png,
+// matching the filenames chop writes out.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	_ "image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// lowerHalfBlock renders two source rows per terminal cell: the foreground
+// color paints the lower pixel, the background color paints the upper pixel.
+const lowerHalfBlock = "▄"
+const upperHalfBlock = "▀"
+
+// grayscaleRamp is used in --no-color mode, chosen by pixel luminance, dark to light.
+const grayscaleRamp = " .:-=+*#%@"
+
+func main() {
+	truecolor := flag.Bool("truecolor", false, "use 24-bit ANSI color instead of the xterm-256 palette")
+	noColor := flag.Bool("no-color", false, "render using a luminance ramp instead of any color")
+	padding := flag.Int("padding", 0, "transparent pixels of padding to add on every side of the sprite (one pixel is one column, or half a row)")
+	trim := flag.Bool("trim", false, "crop to the opaque bounding box before rendering")
+
+	flagArgs, positional := splitFlagsAndPositional(os.Args[1:])
+	flag.CommandLine.Parse(flagArgs)
+
+	if len(positional) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: task render -- [flags] <path.png|name>")
+		fmt.Fprintln(os.Stderr, "Example: task render -- 025-shiny --truecolor")
+		os.Exit(1)
+	}
+
+	path, err := resolveInput(positional[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "render: %v\n", err)
+		os.Exit(1)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "render: %v\n", err)
+		os.Exit(1)
+	}
+	img, _, err := image.Decode(f)
+	f.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "render: decode %s: %v\n", path, err)
+		os.Exit(1)
+	}
+
+	if *trim {
+		img = trimTransparent(img)
+	}
+	if *padding > 0 {
+		img = addPadding(img, *padding)
+	}
+
+	render(os.Stdout, img, *truecolor, *noColor)
+}
+
+// valueFlags lists this command's flags that consume the following argument
+// as their value (e.g. --padding 2), as opposed to boolean flags like
+// --truecolor that don't.
+var valueFlags = map[string]bool{"padding": true}
+
+// splitFlagsAndPositional separates args into flag tokens (for
+// flag.FlagSet.Parse) and positional arguments, regardless of the order
+// they were given in: flag.Parse alone stops at the first non-flag token,
+// which would reject the documented "task render -- 025-shiny --truecolor"
+// form.
+func splitFlagsAndPositional(args []string) (flagArgs, positional []string) {
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(a, "-") {
+			positional = append(positional, a)
+			continue
+		}
+
+		flagArgs = append(flagArgs, a)
+		name := strings.TrimLeft(a, "-")
+		if !strings.Contains(name, "=") && valueFlags[name] && i+1 < len(args) {
+			i++
+			flagArgs = append(flagArgs, args[i])
+		}
+	}
+	return flagArgs, positional
+}
+
+// resolveInput turns a path or bare sprite name into a file on disk.
+func resolveInput(arg string) (string, error) {
+	if _, err := os.Stat(arg); err == nil {
+		return arg, nil
+	}
+	if !strings.ContainsAny(arg, `/\`) {
+		candidate := filepath.Join("images", strings.TrimSuffix(arg, ".png")+".png")
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("could not find sprite %q (looked for it directly and under images/)", arg)
+}
+
+// render writes img to w as a grid of half-block cells, two source rows per cell.
+func render(w *os.File, img image.Image, truecolor, noColor bool) {
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 2 {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			upperR, upperG, upperB, upperA := img.At(x, y).RGBA()
+			var lowerR, lowerG, lowerB, lowerA uint32
+			if y+1 < bounds.Max.Y {
+				lowerR, lowerG, lowerB, lowerA = img.At(x, y+1).RGBA()
+			}
+
+			upperOpaque := upperA>>8 > 0
+			lowerOpaque := lowerA>>8 > 0
+
+			switch {
+			case !upperOpaque && !lowerOpaque:
+				fmt.Fprint(w, "\x1b[0m ")
+			case noColor:
+				fmt.Fprint(w, rampCell(upperOpaque, upperR, upperG, upperB, lowerOpaque, lowerR, lowerG, lowerB))
+			case upperOpaque && lowerOpaque:
+				fmt.Fprintf(w, "%s%s%s\x1b[0m", fgEscape(lowerR, lowerG, lowerB, truecolor), bgEscape(upperR, upperG, upperB, truecolor), lowerHalfBlock)
+			case lowerOpaque:
+				fmt.Fprintf(w, "\x1b[0m%s%s\x1b[0m", fgEscape(lowerR, lowerG, lowerB, truecolor), lowerHalfBlock)
+			default:
+				fmt.Fprintf(w, "\x1b[0m%s%s\x1b[0m", fgEscape(upperR, upperG, upperB, truecolor), upperHalfBlock)
+			}
+		}
+		fmt.Fprint(w, "\x1b[0m\n")
+	}
+}
+
+func rampCell(upperOpaque bool, uR, uG, uB uint32, lowerOpaque bool, lR, lG, lB uint32) string {
+	top := " "
+	bottom := " "
+	if upperOpaque {
+		top = rampChar(uR, uG, uB)
+	}
+	if lowerOpaque {
+		bottom = rampChar(lR, lG, lB)
+	}
+	// No color output is one character per cell; prefer the lower pixel
+	// since it anchors the cell the way the colored half-block does.
+	if lowerOpaque {
+		return bottom
+	}
+	return top
+}
+
+func rampChar(r, g, b uint32) string {
+	lum := luminance(r, g, b)
+	idx := int(lum * float64(len(grayscaleRamp)-1))
+	return string(grayscaleRamp[idx])
+}
+
+func luminance(r, g, b uint32) float64 {
+	// r, g, b are 16-bit (0-65535); convert to 0-1 before weighting.
+	rf, gf, bf := float64(r)/65535, float64(g)/65535, float64(b)/65535
+	return 0.2126*rf + 0.7152*gf + 0.0722*bf
+}
+
+func fgEscape(r, g, b uint32, truecolor bool) string {
+	if truecolor {
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", r>>8, g>>8, b>>8)
+	}
+	return fmt.Sprintf("\x1b[38;5;%dm", nearestXterm256(r>>8, g>>8, b>>8))
+}
+
+func bgEscape(r, g, b uint32, truecolor bool) string {
+	if truecolor {
+		return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", r>>8, g>>8, b>>8)
+	}
+	return fmt.Sprintf("\x1b[48;5;%dm", nearestXterm256(r>>8, g>>8, b>>8))
+}
+
+// trimTransparent crops img to the bounding box of its non-transparent pixels.
+func trimTransparent(img image.Image) image.Image {
+	bounds := img.Bounds()
+	minX, minY := bounds.Max.X, bounds.Max.Y
+	maxX, maxY := bounds.Min.X, bounds.Min.Y
+	found := false
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			_, _, _, a := img.At(x, y).RGBA()
+			if a>>8 == 0 {
+				continue
+			}
+			found = true
+			if x < minX {
+				minX = x
+			}
+			if y < minY {
+				minY = y
+			}
+			if x > maxX {
+				maxX = x
+			}
+			if y > maxY {
+				maxY = y
+			}
+		}
+	}
+	if !found {
+		return img
+	}
+
+	trimmed := image.NewRGBA(image.Rect(0, 0, maxX-minX+1, maxY-minY+1))
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			trimmed.Set(x-minX, y-minY, img.At(x, y))
+		}
+	}
+	return trimmed
+}
+
+// addPadding surrounds img with n fully-transparent pixels on every side.
+func addPadding(img image.Image, n int) image.Image {
+	bounds := img.Bounds()
+	padded := image.NewRGBA(image.Rect(0, 0, bounds.Dx()+2*n, bounds.Dy()+2*n))
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			padded.Set(x-bounds.Min.X+n, y-bounds.Min.Y+n, img.At(x, y))
+		}
+	}
+	return padded
+}
+
+// nearestXterm256 quantizes an 8-bit RGB color to the xterm-256 palette index
+// by nearest neighbor (squared distance) over the 16 named colors, the
+// 6x6x6 color cube, and the 24-step grayscale ramp.
+func nearestXterm256(r, g, b uint32) int {
+	best := 0
+	bestDist := math.MaxFloat64
+	for i, c := range xterm256Palette {
+		dr := float64(r) - float64(c[0])
+		dg := float64(g) - float64(c[1])
+		db := float64(b) - float64(c[2])
+		dist := dr*dr + dg*dg + db*db
+		if dist < bestDist {
+			bestDist = dist
+			best = i
+		}
+	}
+	return best
+}
+
+// xterm256Palette holds the RGB values for all 256 xterm palette entries:
+// 16 named colors, a 6x6x6 color cube, then a 24-step grayscale ramp.
+var xterm256Palette = buildXterm256Palette()
+
+func buildXterm256Palette() [256][3]uint32 {
+	var p [256][3]uint32
+
+	// 16 named ANSI colors (standard xterm defaults).
+	named := [16][3]uint32{
+		{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0},
+		{0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+		{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+		{0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+	}
+	copy(p[0:16], named[:])
+
+	// 6x6x6 color cube (indices 16-231).
+	steps := [6]uint32{0, 95, 135, 175, 215, 255}
+	i := 16
+	for r := 0; r < 6; r++ {
+		for g := 0; g < 6; g++ {
+			for b := 0; b < 6; b++ {
+				p[i] = [3]uint32{steps[r], steps[g], steps[b]}
+				i++
+			}
+		}
+	}
+
+	// 24-step grayscale ramp (indices 232-255).
+	for s := 0; s < 24; s++ {
+		v := uint32(8 + s*10)
+		p[232+s] = [3]uint32{v, v, v}
+	}
+
+	return p
+}